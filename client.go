@@ -2,6 +2,7 @@ package mlflow
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -31,6 +33,11 @@ type CreateOptions struct {
 	// A namespace is a Kubernetes construct, but is useful for managing
 	// experiments in a multi-tenant environment, running on Kubernetes.
 	Namespace string
+
+	// IgnoreAlreadyExists will ignore the RESOURCE_ALREADY_EXISTS error
+	// returned when an experiment with the same name already exists, and
+	// will instead populate the experiment with the existing one.
+	IgnoreAlreadyExists bool
 }
 
 // ListOptions are options that can be passed to the ListExperiments.
@@ -39,6 +46,24 @@ type ListOptions struct {
 	// A namespace is a Kubernetes construct, but is useful for managing
 	// experiments in a multi-tenant environment, running on Kubernetes.
 	Namespace string
+
+	// Filter restricts the returned experiments using MLflow's search
+	// filter syntax. See FilterByTag, FilterByName, And, and Or.
+	Filter Filter
+
+	// ViewType selects whether active, deleted, or all experiments are
+	// returned. Defaults to ViewTypeActiveOnly on the server.
+	ViewType ViewType
+
+	// OrderBy sets the fields to sort results by.
+	OrderBy []string
+
+	// MaxResults caps the number of experiments returned per page.
+	MaxResults int64
+
+	// PageToken resumes listing from a cursor returned by a previous
+	// call's ExperimentList.NextPageToken.
+	PageToken string
 }
 
 type GetOptions struct {
@@ -60,6 +85,10 @@ type GetOption interface {
 	ApplyToGet(*GetOptions)
 }
 
+type ListOption interface {
+	ApplyToList(*ListOptions)
+}
+
 // InNamespace is a CreateOption that sets the target namespace of the
 // resource being created.
 type InNamespace string
@@ -83,25 +112,105 @@ func (i IgnoreMissing) ApplyToDelete(o *DeleteOptions) {
 	o.IgnoreMissing = bool(i)
 }
 
-// Client is the interface for interacting with the MLFlow API
+// IgnoreAlreadyExists is a CreateOption that will force the client to
+// not return an error if the experiment already exists. Instead, the
+// existing experiment is fetched by name and used to populate the
+// experiment pointer, making CreateExperiment idempotent. This mirrors
+// IgnoreMissing on DeleteExperiment.
+type IgnoreAlreadyExists bool
+
+func (i IgnoreAlreadyExists) ApplyToCreate(o *CreateOptions) {
+	o.IgnoreAlreadyExists = bool(i)
+}
+
+// Client is the interface for interacting with the MLFlow API. Every
+// method takes a context.Context as its first argument so callers can
+// enforce per-call timeouts and cancellation, following the same
+// convention used by most Go SDKs that wrap a remote HTTP API.
 type Client interface {
 	// CreateExperiment creates a new experiment. If the experiment
 	// name already exists, then an error is returned. The experiment
 	// will set all computed fields with the response
-	CreateExperiment(experiment *Experiment, opts ...CreateOption) error
+	CreateExperiment(ctx context.Context, experiment *Experiment, opts ...CreateOption) error
 	// DeleteExperiment deletes the provided experiment with the given ID. Experiments
 	// can be deleted by name, but this requires an additional lookup step to find the
 	// experiment ID. If the experiment is not found, then an error is returned.
-	DeleteExperiment(experiment *Experiment, opts ...DeleteOption) error
+	DeleteExperiment(ctx context.Context, experiment *Experiment, opts ...DeleteOption) error
 	// GetExperiment gets the experiment with the given ID or name. If the experiment
 	// is not found, then an error is returned. At least one of the ID or Name must
 	// be set on the experiment. The remaining fields will be set from the response.
-	GetExperiment(experiment *Experiment, opts ...GetOption) error
+	GetExperiment(ctx context.Context, experiment *Experiment, opts ...GetOption) error
 	// UpdateExperiment updates the experiment with the given ID or name. If the
 	// ID and Name are both set, then the ID will be used, and if the name doesn't
 	// match the stored Name, it will be updated. Name, Tags, and LifecycleStage
 	// can all be updated. All other fields are ignored.
-	UpdateExperiment(experiment *Experiment) error
+	UpdateExperiment(ctx context.Context, experiment *Experiment) error
+	// ListExperiments fetches a single page of experiments into list,
+	// matching opts. Use PageToken to fetch subsequent pages.
+	ListExperiments(ctx context.Context, list *ExperimentList, opts ...ListOption) error
+	// SearchExperiments returns an iterator that transparently pages
+	// through every experiment matching opts.
+	SearchExperiments(ctx context.Context, opts ...ListOption) *ExperimentIterator
+
+	// CreateRegisteredModel creates a new registered model. If a
+	// registered model with the same name already exists, then an error
+	// is returned.
+	CreateRegisteredModel(ctx context.Context, model *RegisteredModel, opts ...CreateOption) error
+	// GetRegisteredModel fetches the registered model with the given
+	// name. If the registered model is not found, then an error is
+	// returned.
+	GetRegisteredModel(ctx context.Context, model *RegisteredModel, opts ...GetOption) error
+	// DeleteRegisteredModel deletes the registered model with the given
+	// name. If the registered model is not found, then an error is
+	// returned.
+	DeleteRegisteredModel(ctx context.Context, model *RegisteredModel, opts ...DeleteOption) error
+	// SearchRegisteredModels returns an iterator that transparently pages
+	// through every registered model matching opts.
+	SearchRegisteredModels(ctx context.Context, opts ...ListOption) *RegisteredModelIterator
+	// CreateModelVersion registers a new ModelVersion under the
+	// registered model named version.Name.
+	CreateModelVersion(ctx context.Context, version *ModelVersion, opts ...CreateOption) error
+	// TransitionModelVersionStage moves a model version to stage,
+	// optionally archiving any existing versions already in that stage.
+	TransitionModelVersionStage(ctx context.Context, version *ModelVersion, stage ModelVersionStage, archiveExisting bool, opts ...GetOption) error
+	// SetRegisteredModelAlias points alias at version on the named
+	// registered model, creating or overwriting it.
+	SetRegisteredModelAlias(ctx context.Context, model *RegisteredModel, alias, version string, opts ...GetOption) error
+	// DeleteRegisteredModelAlias removes alias from the named registered
+	// model.
+	DeleteRegisteredModelAlias(ctx context.Context, model *RegisteredModel, alias string, opts ...GetOption) error
+	// GetModelVersionByAlias resolves alias on the named registered
+	// model to its current ModelVersion.
+	GetModelVersionByAlias(ctx context.Context, model *RegisteredModel, alias string, opts ...GetOption) (*ModelVersion, error)
+
+	// CreateRun starts a new run under run.Info.ExperimentID.
+	CreateRun(ctx context.Context, run *Run, opts ...CreateOption) error
+	// GetRun fetches the run with the given run.Info.RunID.
+	GetRun(ctx context.Context, run *Run) error
+	// UpdateRun updates the status and end time of run.Info.RunID.
+	UpdateRun(ctx context.Context, run *Run) error
+	// LogParam logs a single param against runID. Params are immutable
+	// once set.
+	LogParam(ctx context.Context, runID string, param Param) error
+	// LogMetric logs a single metric data point against runID.
+	LogMetric(ctx context.Context, runID string, metric Metric) error
+	// LogBatch logs a batch of metrics, params, and tags against runID in
+	// a single call.
+	LogBatch(ctx context.Context, runID string, metrics []Metric, params []Param, tags []RunTag) error
+	// SearchRuns returns an iterator that transparently pages through
+	// every run matching opts, scoped to experimentIDs.
+	SearchRuns(ctx context.Context, experimentIDs []string, opts ...ListOption) *RunIterator
+	// StreamMetricHistory streams the history of metricKey on runID,
+	// optionally following for new points as they're logged.
+	StreamMetricHistory(ctx context.Context, runID, metricKey string, opts ...StreamOption) (<-chan Metric, <-chan error)
+
+	// RegisterHook registers fn to run whenever an Experiment reaches
+	// stage.
+	RegisterHook(stage HookStage, fn Hook)
+	// RunStartupHooks runs every HookStageStartup hook once against each
+	// experiment matching opts, persisting any mutations back to the
+	// server.
+	RunStartupHooks(ctx context.Context, opts ...ListOption) error
 }
 
 type client struct {
@@ -109,10 +218,117 @@ type client struct {
 	httpClient *http.Client
 
 	authenticator func(*http.Request)
+	retryPolicy   RetryPolicy
+
+	hooks map[HookStage][]Hook
+}
+
+// newRequest builds an *http.Request bound to ctx for the given method and
+// API path, relative to the client's configured address. If body is
+// non-nil, it is JSON-encoded as the request body.
+func (c *client) newRequest(ctx context.Context, method, p string, body interface{}) (*http.Request, error) {
+	u := mustCopyURL(c.address)
+	u.Path = path.Join(u.Path, p)
+
+	var buf io.Reader
+	if body != nil {
+		b := new(bytes.Buffer)
+		if err := json.NewEncoder(b).Encode(body); err != nil {
+			return nil, err
+		}
+		buf = b
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.authenticator != nil {
+		c.authenticator(req)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// do executes req, retrying on 429/5xx responses and transient network
+// errors according to c.retryPolicy when req's method is idempotent or
+// req was built from a context marked with withIdempotentRetry. On
+// a 200 response it decodes the body into out (when out is non-nil); any
+// other status code is returned as an *APIError.
+func (c *client) do(req *http.Request, out interface{}) error {
+	policy := c.retryPolicy
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	var lastErr error
+	var retryAfter time.Duration
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			delay := retryAfter
+			if delay == 0 {
+				delay = backoffDelay(policy, attempt-1)
+			}
+			select {
+			case <-time.After(delay):
+			case <-req.Context().Done():
+				return req.Context().Err()
+			}
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return err
+				}
+				req.Body = body
+			}
+			retryAfter = 0
+		}
+
+		res, err := httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if !isIdempotentRequest(req) {
+				return err
+			}
+			continue
+		}
+
+		data, readErr := io.ReadAll(res.Body)
+		res.Body.Close()
+		if readErr != nil {
+			return readErr
+		}
+
+		if res.StatusCode == http.StatusOK {
+			if out == nil {
+				return nil
+			}
+			return json.Unmarshal(data, out)
+		}
+
+		apiErr := parseAPIError(res.StatusCode, data)
+		lastErr = apiErr
+
+		if !isIdempotentRequest(req) || !isRetryableStatus(res.StatusCode) || attempt == policy.MaxAttempts {
+			return apiErr
+		}
+		retryAfter, _ = parseRetryAfter(res.Header.Get("Retry-After"))
+	}
+	return lastErr
 }
 
 // CreateExperiment creates a new experiment
-func (c *client) CreateExperiment(experiment *Experiment, opts ...CreateOption) error {
+func (c *client) CreateExperiment(ctx context.Context, experiment *Experiment, opts ...CreateOption) error {
 	if experiment.Name == "" {
 		return errors.Errorf("missing required attribute %q on experiment", "Name")
 	}
@@ -126,8 +342,9 @@ func (c *client) CreateExperiment(experiment *Experiment, opts ...CreateOption)
 		o.Namespace = "default"
 	}
 
-	u := mustCopyURL(c.address)
-	u.Path = path.Join(u.Path, "/api/2.0/mlflow/experiments/create")
+	if err := c.runHooks(HookStageBeforeCreate, experiment); err != nil {
+		return err
+	}
 
 	var in struct {
 		Name             string `json:"name"`
@@ -139,57 +356,53 @@ func (c *client) CreateExperiment(experiment *Experiment, opts ...CreateOption)
 	in.Tags = experiment.Tags
 	in.Tags.Set("metadata.namespace", o.Namespace)
 
-	buf := new(bytes.Buffer)
-	err := json.NewEncoder(buf).Encode(in)
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/experiments/create", in)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), buf)
-	if err != nil {
-		return err
+	var out struct {
+		ExperimentID string `json:"experiment_id"`
 	}
-
-	if c.authenticator != nil {
-		c.authenticator(req)
+	if err := c.do(req, &out); err != nil {
+		if o.IgnoreAlreadyExists && IsAlreadyExists(err) {
+			return c.getExperimentByName(ctx, experiment, o.Namespace)
+		}
+		return err
 	}
 
-	if c.httpClient == nil {
-		c.httpClient = http.DefaultClient
+	experiment.ExperimentID = out.ExperimentID
+	if err := c.GetExperiment(ctx, experiment, InNamespace(o.Namespace)); err != nil {
+		return err
 	}
+	return c.runHooks(HookStageAfterCreate, experiment)
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	res, err := c.httpClient.Do(req)
+// getExperimentByName looks up an experiment by its namespace-qualified
+// name and populates experiment with the response.
+func (c *client) getExperimentByName(ctx context.Context, experiment *Experiment, namespace string) error {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/2.0/mlflow/experiments/get-by-name", nil)
 	if err != nil {
 		return err
 	}
-	body := res.Body
-	defer body.Close()
 
-	if res.StatusCode != http.StatusOK {
-		var data []byte
-		data, err = io.ReadAll(body)
-		if err != nil {
-			return err
-		}
-		return errors.Errorf("unexpected status code %d: %s", res.StatusCode, string(data))
-	}
+	q := req.URL.Query()
+	q.Set("experiment_name", fmt.Sprintf("%s/%s", namespace, experiment.Name))
+	req.URL.RawQuery = q.Encode()
 
 	var out struct {
-		ExperimentID string `json:"experiment_id"`
+		Experiment `json:"experiment"`
 	}
-
-	err = json.NewDecoder(body).Decode(&out)
-	if err != nil {
+	if err := c.do(req, &out); err != nil {
 		return err
 	}
 
-	experiment.ExperimentID = out.ExperimentID
-	return c.GetExperiment(experiment, InNamespace(o.Namespace))
+	out.DeepCopyInto(experiment)
+	trimNamespacePrefix(experiment)
+	return nil
 }
 
-func (c *client) GetExperiment(experiment *Experiment, opts ...GetOption) error {
+func (c *client) GetExperiment(ctx context.Context, experiment *Experiment, opts ...GetOption) error {
 	if experiment.ExperimentID == "" {
 		return errors.Errorf("ExperimentID must be set")
 	}
@@ -203,63 +416,103 @@ func (c *client) GetExperiment(experiment *Experiment, opts ...GetOption) error
 		o.Namespace = "default"
 	}
 
-	u := mustCopyURL(c.address)
-	u.Path = path.Join(u.Path, "/api/2.0/mlflow/experiments/get")
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/2.0/mlflow/experiments/get", nil)
+	if err != nil {
+		return err
+	}
 
-	q := u.Query()
+	q := req.URL.Query()
 	q.Set("experiment_id", experiment.ExperimentID)
-	u.RawQuery = q.Encode()
+	req.URL.RawQuery = q.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
-	if err != nil {
+	var out struct {
+		Experiment `json:"experiment"`
+	}
+	if err := c.do(req, &out); err != nil {
 		return err
 	}
-	req.Header.Set("Accept", "application/json")
 
-	if c.authenticator != nil {
-		c.authenticator(req)
+	out.DeepCopyInto(experiment)
+	trimNamespacePrefix(experiment)
+	return c.runHooks(HookStageAfterGet, experiment)
+}
+
+// trimNamespacePrefix strips the "<namespace>/" prefix that
+// CreateExperiment adds to the experiment name, so callers always see
+// the name they originally supplied.
+func trimNamespacePrefix(experiment *Experiment) {
+	namespace := experiment.Tags.Get("metadata.namespace")
+	if namespace != "" {
+		experiment.Name = strings.TrimPrefix(experiment.Name, namespace+"/")
 	}
+}
 
-	if c.httpClient == nil {
-		c.httpClient = http.DefaultClient
+func (c *client) UpdateExperiment(ctx context.Context, experiment *Experiment) error {
+	if experiment.ExperimentID == "" {
+		return errors.Errorf("ExperimentID must be set")
 	}
 
-	res, err := c.httpClient.Do(req)
-	if err != nil {
+	if err := c.runHooks(HookStageBeforeUpdate, experiment); err != nil {
 		return err
 	}
 
-	body := res.Body
-	defer body.Close()
+	namespace := experiment.GetNamespace()
+	if namespace == "" {
+		namespace = "default"
+	}
 
-	if res.StatusCode != http.StatusOK {
-		var data []byte
-		data, err = io.ReadAll(body)
-		if err != nil {
-			return err
-		}
-		return errors.Errorf("unexpected status code %d: %s", res.StatusCode, string(data))
+	current := &Experiment{ExperimentID: experiment.ExperimentID}
+	if err := c.GetExperiment(ctx, current, InNamespace(namespace)); err != nil {
+		return err
 	}
 
-	var out struct {
-		Experiment `json:"experiment"`
+	var in struct {
+		ExperimentID string `json:"experiment_id"`
+		NewName      string `json:"new_name,omitempty"`
+	}
+	in.ExperimentID = experiment.ExperimentID
+	if experiment.Name != current.Name {
+		in.NewName = fmt.Sprintf("%s/%s", namespace, experiment.Name)
 	}
 
-	err = json.NewDecoder(body).Decode(&out)
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/experiments/update", in)
 	if err != nil {
 		return err
 	}
+	if err := c.do(req, nil); err != nil {
+		return err
+	}
 
-	out.DeepCopyInto(experiment)
-	namespace := experiment.Tags.Get("metadata.namespace")
-	if namespace != "" {
-		prefix := fmt.Sprintf("%s/", namespace)
-		experiment.Name = strings.TrimPrefix(experiment.Name, prefix)
+	for _, tag := range experiment.Tags {
+		var tagIn struct {
+			ExperimentID string `json:"experiment_id"`
+			Key          string `json:"key"`
+			Value        string `json:"value"`
+		}
+		tagIn.ExperimentID = experiment.ExperimentID
+		tagIn.Key = tag.Key
+		tagIn.Value = tag.Value
+
+		tagReq, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/experiments/set-experiment-tag", tagIn)
+		if err != nil {
+			return err
+		}
+		if err := c.do(tagReq, nil); err != nil {
+			return err
+		}
 	}
-	return nil
+
+	if experiment.LifecycleStage == LifecycleStageDeleted {
+		if err := c.DeleteExperiment(ctx, experiment); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	return c.GetExperiment(ctx, experiment, InNamespace(namespace))
 }
 
-func (c *client) DeleteExperiment(experiment *Experiment, opts ...DeleteOption) error {
+func (c *client) DeleteExperiment(ctx context.Context, experiment *Experiment, opts ...DeleteOption) error {
 	if experiment.ExperimentID == "" {
 		return errors.Errorf("ExperimentID must be set")
 	}
@@ -273,57 +526,30 @@ func (c *client) DeleteExperiment(experiment *Experiment, opts ...DeleteOption)
 		o.Namespace = "default"
 	}
 
-	u := mustCopyURL(c.address)
-	u.Path = path.Join(u.Path, "/api/2.0/mlflow/experiments/delete")
+	if err := c.runHooks(HookStageBeforeDelete, experiment); err != nil {
+		return err
+	}
 
 	var body struct {
 		ExperimentID string `json:"experiment_id"`
 	}
 	body.ExperimentID = experiment.ExperimentID
 
-	buf := new(bytes.Buffer)
-	err := json.NewEncoder(buf).Encode(body)
+	req, err := c.newRequest(withIdempotentRetry(ctx), http.MethodPost, "/api/2.0/mlflow/experiments/delete", body)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), buf)
-	if err != nil {
-		return err
-	}
-
-	if c.authenticator != nil {
-		c.authenticator(req)
-	}
-
-	if c.httpClient == nil {
-		c.httpClient = http.DefaultClient
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	res, err := c.httpClient.Do(req)
-	if err != nil {
+	if err := c.do(req, nil); err != nil {
+		if o.IgnoreMissing && IsNotFound(err) {
+			*experiment = Experiment{}
+			return nil
+		}
 		return err
 	}
 
-	if res.StatusCode == http.StatusOK {
-		empty := &Experiment{}
-		empty.DeepCopyInto(experiment)
-		return nil
-	}
-
-	if res.StatusCode == http.StatusNotFound && o.IgnoreMissing {
-		empty := &Experiment{}
-		empty.DeepCopyInto(experiment)
-		return nil
-	}
-
-	data, err := io.ReadAll(res.Body)
-	if err != nil {
-		return err
-	}
-	return errors.Errorf("unexpected status code %d: %s", res.StatusCode, string(data))
+	*experiment = Experiment{}
+	return nil
 }
 
 func mustCopyURL(in *url.URL) *url.URL {
@@ -1,6 +1,7 @@
 package mlflow
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -9,31 +10,60 @@ import (
 )
 
 func TestClient_CreateExperiment(t *testing.T) {
+	ctx := context.Background()
 	exp := &Experiment{Name: "create-experiment-" + uuid.NewString()[:7]}
 	c := &client{address: mustParseURL("http://localhost:5000")}
-	assert.NoError(t, c.CreateExperiment(exp))
+	assert.NoError(t, c.CreateExperiment(ctx, exp))
 	assert.NotEmpty(t, exp.ExperimentID)
 	assert.False(t, exp.GetCreationTimestamp().IsZero())
 	assert.True(t, exp.GetTags().Contains("metadata.namespace"))
 	assert.False(t, strings.HasPrefix(exp.GetName(), "default"))
 	exp0 := &Experiment{}
 	exp0.SetName(exp.GetName())
-	assert.Error(t, c.CreateExperiment(exp0))
-	assert.NoError(t, c.CreateExperiment(exp0, IgnoreAlreadyExists(true)))
+	assert.Error(t, c.CreateExperiment(ctx, exp0))
+	assert.NoError(t, c.CreateExperiment(ctx, exp0, IgnoreAlreadyExists(true)))
 	assert.NotEmpty(t, exp.ExperimentID)
 	assert.False(t, exp.GetCreationTimestamp().IsZero())
 	assert.True(t, exp.GetTags().Contains("metadata.namespace"))
 	assert.False(t, strings.HasPrefix(exp.GetName(), "default"))
 }
 
+func TestClient_UpdateExperiment(t *testing.T) {
+	ctx := context.Background()
+	ns := "update-experiment-" + uuid.NewString()[:7]
+	exp := &Experiment{Name: "exp-" + uuid.NewString()[:7]}
+	c := &client{address: mustParseURL("http://localhost:5000")}
+	assert.NoError(t, c.CreateExperiment(ctx, exp, InNamespace(ns)))
+
+	// Updating only a tag must not touch the server-side name, which
+	// stays namespace-prefixed even though exp.Name is the trimmed form.
+	exp.GetTags().Set("owner", "team-a")
+	assert.NoError(t, c.UpdateExperiment(ctx, exp))
+
+	byName := &Experiment{Name: exp.GetName()}
+	assert.NoError(t, c.getExperimentByName(ctx, byName, ns))
+	assert.Equal(t, exp.ExperimentID, byName.ExperimentID)
+	assert.Equal(t, "team-a", byName.GetTags().Get("owner"))
+
+	newName := "renamed-" + uuid.NewString()[:7]
+	exp.SetName(newName)
+	assert.NoError(t, c.UpdateExperiment(ctx, exp))
+	assert.Equal(t, newName, exp.GetName())
+
+	renamed := &Experiment{Name: newName}
+	assert.NoError(t, c.getExperimentByName(ctx, renamed, ns))
+	assert.Equal(t, exp.ExperimentID, renamed.ExperimentID)
+}
+
 func TestClient_DeleteExperiment(t *testing.T) {
+	ctx := context.Background()
 	exp := &Experiment{Name: "delete-experiment-" + uuid.NewString()[:7]}
 	c := &client{address: mustParseURL("http://localhost:5000")}
-	assert.NoError(t, c.CreateExperiment(exp))
+	assert.NoError(t, c.CreateExperiment(ctx, exp))
 	exp0 := &Experiment{}
 	exp.DeepCopyInto(exp0)
-	assert.NoError(t, c.DeleteExperiment(exp0))
+	assert.NoError(t, c.DeleteExperiment(ctx, exp0))
 	assert.Equal(t, &Experiment{}, exp0)
-	assert.NoError(t, c.DeleteExperiment(exp, IgnoreMissing(true)))
+	assert.NoError(t, c.DeleteExperiment(ctx, exp, IgnoreMissing(true)))
 	assert.Equal(t, &Experiment{}, exp)
 }
@@ -0,0 +1,88 @@
+package mlflow
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// HookStage identifies the point in an Experiment's lifecycle at which a
+// registered hook runs.
+type HookStage string
+
+const (
+	// HookStageBeforeCreate runs before an experiment is created on the
+	// server. Hooks at this stage can default tags or the artifact
+	// location before the request is sent.
+	HookStageBeforeCreate HookStage = "BeforeCreate"
+	// HookStageAfterCreate runs after an experiment has been created and
+	// fetched back from the server.
+	HookStageAfterCreate HookStage = "AfterCreate"
+	// HookStageBeforeDelete runs before an experiment is deleted. A hook
+	// returning an error aborts the delete.
+	HookStageBeforeDelete HookStage = "BeforeDelete"
+	// HookStageAfterGet runs after GetExperiment has fetched an
+	// experiment from the server, including the GetExperiment calls made
+	// internally by CreateExperiment and UpdateExperiment. It does not run
+	// for experiments returned by ListExperiments or SearchExperiments, or
+	// for the by-name lookup CreateExperiment performs with
+	// IgnoreAlreadyExists.
+	HookStageAfterGet HookStage = "AfterGet"
+	// HookStageBeforeUpdate runs before an experiment's changes are sent
+	// to the server. Mutations made by the hook are reflected in the
+	// request.
+	HookStageBeforeUpdate HookStage = "BeforeUpdate"
+	// HookStageStartup runs once per experiment in RunStartupHooks,
+	// ahead of any other stage, so a tracking server's existing
+	// experiments can be migrated to a new tag schema or policy.
+	HookStageStartup HookStage = "Startup"
+)
+
+// Hook mutates or validates an Experiment at a given HookStage. Returning
+// an error aborts the operation the hook is attached to.
+type Hook func(*Experiment) error
+
+// RegisterHook registers fn to run whenever an Experiment reaches stage.
+// Hooks run in the order they were registered. This enables callers to
+// enforce tag policies, auto-tag experiments with a git SHA or user
+// identity, or migrate legacy tag schemas without wrapping the Client
+// themselves.
+func (c *client) RegisterHook(stage HookStage, fn Hook) {
+	if c.hooks == nil {
+		c.hooks = make(map[HookStage][]Hook)
+	}
+	c.hooks[stage] = append(c.hooks[stage], fn)
+}
+
+// runHooks runs every hook registered at stage against experiment, in
+// registration order, stopping at the first error.
+func (c *client) runHooks(stage HookStage, experiment *Experiment) error {
+	for _, fn := range c.hooks[stage] {
+		if err := fn(experiment); err != nil {
+			return errors.Wrapf(err, "%s hook", stage)
+		}
+	}
+	return nil
+}
+
+// RunStartupHooks runs every HookStageStartup hook once against each
+// experiment matching opts, persisting any mutations back to the server
+// with UpdateExperiment. It's intended to be called once when a program
+// starts up, to migrate or backfill every experiment in a namespace.
+func (c *client) RunStartupHooks(ctx context.Context, opts ...ListOption) error {
+	if len(c.hooks[HookStageStartup]) == 0 {
+		return nil
+	}
+
+	it := c.SearchExperiments(ctx, opts...)
+	for it.Next() {
+		experiment := it.Item()
+		if err := c.runHooks(HookStageStartup, experiment); err != nil {
+			return errors.Wrapf(err, "experiment %q", experiment.Name)
+		}
+		if err := c.UpdateExperiment(ctx, experiment); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,40 @@
+package mlflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RunStartupHooks(t *testing.T) {
+	ctx := context.Background()
+	ns := "startup-hooks-" + uuid.NewString()[:7]
+	exp := &Experiment{Name: "exp-" + uuid.NewString()[:7]}
+	c := &client{address: mustParseURL("http://localhost:5000")}
+	assert.NoError(t, c.CreateExperiment(ctx, exp, InNamespace(ns)))
+
+	var ran bool
+	c.RegisterHook(HookStageStartup, func(e *Experiment) error {
+		ran = true
+		e.GetTags().Set("migrated", "true")
+		return nil
+	})
+
+	assert.NoError(t, c.RunStartupHooks(ctx, InNamespace(ns)))
+	assert.True(t, ran)
+
+	got := &Experiment{ExperimentID: exp.ExperimentID}
+	assert.NoError(t, c.GetExperiment(ctx, got, InNamespace(ns)))
+	assert.Equal(t, exp.GetName(), got.GetName())
+	assert.Equal(t, "true", got.GetTags().Get("migrated"))
+
+	// The server-side name must still carry the namespace prefix after
+	// the startup-hook pass persists the hook's mutation — get-by-name
+	// under the same namespace only succeeds if UpdateExperiment didn't
+	// strip it off.
+	byName := &Experiment{Name: got.GetName()}
+	assert.NoError(t, c.getExperimentByName(ctx, byName, ns))
+	assert.Equal(t, exp.ExperimentID, byName.ExperimentID)
+}
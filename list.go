@@ -0,0 +1,237 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ViewType controls which lifecycle stage of experiments are returned by
+// ListExperiments and SearchExperiments.
+type ViewType string
+
+const (
+	ViewTypeActiveOnly  ViewType = "ACTIVE_ONLY"
+	ViewTypeDeletedOnly ViewType = "DELETED_ONLY"
+	ViewTypeAll         ViewType = "ALL"
+)
+
+func (v ViewType) ApplyToList(o *ListOptions) {
+	o.ViewType = v
+}
+
+// FilterOp is a comparison operator supported by MLflow's experiment
+// search filter syntax.
+type FilterOp string
+
+const (
+	OpEquals    FilterOp = "="
+	OpNotEquals FilterOp = "!="
+	OpLike      FilterOp = "LIKE"
+	OpILike     FilterOp = "ILIKE"
+)
+
+// Filter is a predicate in MLflow's search filter DSL (the same syntax
+// accepted by the MLflow UI's search box, e.g. `tags.team = 'ml-infra'
+// AND name LIKE 'training-%'`). Filters can be combined with And and Or.
+type Filter interface {
+	ListOption
+	String() string
+}
+
+type filterExpr string
+
+func (f filterExpr) String() string {
+	return string(f)
+}
+
+func (f filterExpr) ApplyToList(o *ListOptions) {
+	o.Filter = f
+}
+
+// FilterByTag returns a Filter that matches experiments whose tag value
+// for key compares to value using op, e.g.
+// FilterByTag(OpEquals, "team", "ml-infra"). There is no default op.
+func FilterByTag(op FilterOp, key, value string) Filter {
+	return filterExpr(fmt.Sprintf("tags.%s %s %s", key, op, quoteFilterValue(value)))
+}
+
+// FilterByName returns a Filter that compares the experiment name using
+// op, e.g. FilterByName(OpLike, "training-%").
+func FilterByName(op FilterOp, value string) Filter {
+	return filterExpr(fmt.Sprintf("name %s %s", op, quoteFilterValue(value)))
+}
+
+// And combines filters into a single Filter that matches only when every
+// one of them matches.
+func And(filters ...Filter) Filter {
+	return joinFilters(filters, "AND")
+}
+
+// Or combines filters into a single Filter that matches when any one of
+// them matches.
+func Or(filters ...Filter) Filter {
+	return joinFilters(filters, "OR")
+}
+
+func joinFilters(filters []Filter, sep string) Filter {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		parts[i] = fmt.Sprintf("(%s)", f.String())
+	}
+	return filterExpr(strings.Join(parts, fmt.Sprintf(" %s ", sep)))
+}
+
+func quoteFilterValue(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `\'`))
+}
+
+// OrderBy sets the fields MLflow should sort search results by, e.g.
+// OrderBy("attribute.creation_time DESC").
+type OrderBy []string
+
+func (o OrderBy) ApplyToList(l *ListOptions) {
+	l.OrderBy = o
+}
+
+// MaxResults caps the number of experiments returned per page.
+type MaxResults int64
+
+func (m MaxResults) ApplyToList(o *ListOptions) {
+	o.MaxResults = int64(m)
+}
+
+// PageToken resumes a ListExperiments call from the given cursor, as
+// returned on a previous ExperimentList.NextPageToken.
+type PageToken string
+
+func (p PageToken) ApplyToList(o *ListOptions) {
+	o.PageToken = string(p)
+}
+
+func (i InNamespace) ApplyToList(o *ListOptions) {
+	o.Namespace = string(i)
+}
+
+// ExperimentList is a single page of results from ListExperiments.
+type ExperimentList struct {
+	Experiments []Experiment `json:"experiments"`
+	// NextPageToken, when non-empty, can be passed as a PageToken option
+	// to retrieve the next page of results.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// ListExperiments fetches a single page of experiments matching opts. Use
+// PageToken to retrieve subsequent pages, or SearchExperiments for an
+// iterator that pages through the full result set automatically.
+func (c *client) ListExperiments(ctx context.Context, list *ExperimentList, opts ...ListOption) error {
+	o := &ListOptions{}
+	for _, f := range opts {
+		f.ApplyToList(o)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	namespaceFilter := FilterByTag(OpEquals, "metadata.namespace", o.Namespace)
+	filter := Filter(namespaceFilter)
+	if o.Filter != nil {
+		filter = And(namespaceFilter, o.Filter)
+	}
+
+	var in struct {
+		Filter     string   `json:"filter,omitempty"`
+		MaxResults int64    `json:"max_results,omitempty"`
+		PageToken  string   `json:"page_token,omitempty"`
+		OrderBy    []string `json:"order_by,omitempty"`
+		ViewType   ViewType `json:"view_type,omitempty"`
+	}
+	in.Filter = filter.String()
+	in.MaxResults = o.MaxResults
+	in.PageToken = o.PageToken
+	in.OrderBy = o.OrderBy
+	in.ViewType = o.ViewType
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/experiments/search", in)
+	if err != nil {
+		return err
+	}
+
+	var out ExperimentList
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	for i := range out.Experiments {
+		trimNamespacePrefix(&out.Experiments[i])
+	}
+
+	*list = out
+	return nil
+}
+
+// SearchExperiments returns an iterator over every experiment matching
+// opts, transparently paging through results as the caller calls Next.
+func (c *client) SearchExperiments(ctx context.Context, opts ...ListOption) *ExperimentIterator {
+	return &ExperimentIterator{ctx: ctx, client: c, opts: opts}
+}
+
+// ExperimentIterator pages through the results of SearchExperiments.
+// Callers should loop on Next, read Item within the loop, and check Err
+// once the loop ends.
+type ExperimentIterator struct {
+	ctx    context.Context
+	client *client
+	opts   []ListOption
+
+	page      ExperimentList
+	index     int
+	pageToken string
+	done      bool
+	started   bool
+	err       error
+}
+
+// Next advances the iterator, fetching the next page from the server as
+// needed. It returns false when iteration is complete or an error has
+// occurred; check Err to distinguish between the two.
+func (it *ExperimentIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.page.Experiments) {
+		if it.started && it.done {
+			return false
+		}
+
+		opts := append(append([]ListOption{}, it.opts...), PageToken(it.pageToken))
+		page := &ExperimentList{}
+		if err := it.client.ListExperiments(it.ctx, page, opts...); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.page = *page
+		it.index = 0
+		it.pageToken = page.NextPageToken
+		it.done = page.NextPageToken == ""
+	}
+
+	it.index++
+	return true
+}
+
+// Item returns the experiment at the iterator's current position. It
+// must only be called after a call to Next that returned true.
+func (it *ExperimentIterator) Item() *Experiment {
+	return &it.page.Experiments[it.index-1]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *ExperimentIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,584 @@
+package mlflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ModelVersionStage is the stage of a ModelVersion in its lifecycle.
+type ModelVersionStage string
+
+const (
+	ModelVersionStageNone       ModelVersionStage = "None"
+	ModelVersionStageStaging    ModelVersionStage = "Staging"
+	ModelVersionStageProduction ModelVersionStage = "Production"
+	ModelVersionStageArchived   ModelVersionStage = "Archived"
+)
+
+// RegisteredModel is a named, versioned collection of model artifacts in
+// the MLflow Model Registry. It is the registry's analogue of an
+// Experiment: a stable name that groups a series of ModelVersions.
+type RegisteredModel struct {
+	// Name is a human readable identifier for the registered model. The
+	// name field must be set when creating a new registered model.
+	Name string `json:"name"`
+	// Description is a free-form description of the registered model.
+	Description string `json:"description,omitempty"`
+	// CreationTimestamp is the unix timestamp (in milliseconds) of when
+	// the registered model was created. Computed by the server.
+	CreationTimestamp int64 `json:"creation_timestamp,omitempty"`
+	// LastUpdatedTimestamp is the unix timestamp (in milliseconds) of
+	// when the registered model was last updated. Computed by the server.
+	LastUpdatedTimestamp int64 `json:"last_updated_timestamp,omitempty"`
+	// Tags is a list of key-value pairs associated with the registered
+	// model.
+	Tags Tags `json:"tags,omitempty"`
+	// Aliases are the named pointers to specific model versions, such as
+	// "champion" or "production".
+	Aliases []ModelAlias `json:"aliases,omitempty"`
+	// LatestVersions holds the most recent ModelVersion for each stage.
+	// It is computed by the server and populated on Get/Search.
+	LatestVersions []ModelVersion `json:"latest_versions,omitempty"`
+}
+
+// DeepCopy returns a deep copy of the RegisteredModel.
+func (m *RegisteredModel) DeepCopy() *RegisteredModel {
+	out := &RegisteredModel{}
+	m.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the attributes of the RegisteredModel into the
+// provided RegisteredModel.
+func (m *RegisteredModel) DeepCopyInto(out *RegisteredModel) {
+	*out = *m
+	out.Tags = make(Tags, len(m.Tags))
+	copy(out.Tags, m.Tags)
+	out.Aliases = make([]ModelAlias, len(m.Aliases))
+	copy(out.Aliases, m.Aliases)
+	out.LatestVersions = make([]ModelVersion, len(m.LatestVersions))
+	for i := range m.LatestVersions {
+		m.LatestVersions[i].DeepCopyInto(&out.LatestVersions[i])
+	}
+}
+
+// GetName returns the registered model name.
+func (m *RegisteredModel) GetName() string {
+	return m.Name
+}
+
+// SetName sets the registered model name attribute.
+func (m *RegisteredModel) SetName(name string) {
+	m.Name = name
+}
+
+// GetNamespace returns the registered model namespace tag.
+func (m *RegisteredModel) GetNamespace() string {
+	return m.Tags.Get("metadata.namespace")
+}
+
+// SetNamespace sets the registered model namespace tag.
+func (m *RegisteredModel) SetNamespace(namespace string) {
+	m.Tags.Set("metadata.namespace", namespace)
+}
+
+// GetTags returns the registered model tags.
+func (m *RegisteredModel) GetTags() *Tags {
+	return &m.Tags
+}
+
+// SetTags sets the registered model tags attribute.
+func (m *RegisteredModel) SetTags(tags *Tags) {
+	m.Tags = *tags
+}
+
+// ModelAlias is a named pointer from a RegisteredModel to one of its
+// ModelVersions, such as "champion" or "production".
+type ModelAlias struct {
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+// ModelVersion is a single versioned model artifact registered under a
+// RegisteredModel.
+type ModelVersion struct {
+	// Name is the name of the RegisteredModel this version belongs to.
+	Name string `json:"name"`
+	// Version is the version number, assigned by the server.
+	Version string `json:"version,omitempty"`
+	// Source is the location of the model artifacts, e.g. a run's
+	// artifact URI.
+	Source string `json:"source"`
+	// RunID is the ID of the run that produced the model artifacts, if
+	// any.
+	RunID string `json:"run_id,omitempty"`
+	// Description is a free-form description of the model version.
+	Description string `json:"description,omitempty"`
+	// CurrentStage is the lifecycle stage of the model version.
+	CurrentStage ModelVersionStage `json:"current_stage,omitempty"`
+	// CreationTimestamp is the unix timestamp (in milliseconds) of when
+	// the model version was created. Computed by the server.
+	CreationTimestamp int64 `json:"creation_timestamp,omitempty"`
+	// LastUpdatedTimestamp is the unix timestamp (in milliseconds) of
+	// when the model version was last updated. Computed by the server.
+	LastUpdatedTimestamp int64 `json:"last_updated_timestamp,omitempty"`
+	// Tags is a list of key-value pairs associated with the model
+	// version.
+	Tags Tags `json:"tags,omitempty"`
+}
+
+// DeepCopy returns a deep copy of the ModelVersion.
+func (v *ModelVersion) DeepCopy() *ModelVersion {
+	out := &ModelVersion{}
+	v.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the attributes of the ModelVersion into the
+// provided ModelVersion.
+func (v *ModelVersion) DeepCopyInto(out *ModelVersion) {
+	*out = *v
+	out.Tags = make(Tags, len(v.Tags))
+	copy(out.Tags, v.Tags)
+}
+
+// GetTags returns the model version tags.
+func (v *ModelVersion) GetTags() *Tags {
+	return &v.Tags
+}
+
+// SetTags sets the model version tags attribute.
+func (v *ModelVersion) SetTags(tags *Tags) {
+	v.Tags = *tags
+}
+
+// RegisteredModelList is a single page of results from
+// SearchRegisteredModels.
+type RegisteredModelList struct {
+	RegisteredModels []RegisteredModel `json:"registered_models"`
+	// NextPageToken, when non-empty, can be used to retrieve the next
+	// page of results.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+func trimRegisteredModelNamespacePrefix(model *RegisteredModel) {
+	namespace := model.Tags.Get("metadata.namespace")
+	if namespace != "" {
+		model.Name = strings.TrimPrefix(model.Name, namespace+"/")
+	}
+}
+
+// CreateRegisteredModel creates a new registered model. If a registered
+// model with the same name already exists, then an error is returned.
+func (c *client) CreateRegisteredModel(ctx context.Context, model *RegisteredModel, opts ...CreateOption) error {
+	if model.Name == "" {
+		return errors.Errorf("missing required attribute %q on registered model", "Name")
+	}
+
+	o := &CreateOptions{}
+	for _, f := range opts {
+		f.ApplyToCreate(o)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	var in struct {
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Tags        Tags   `json:"tags,omitempty"`
+	}
+	in.Name = fmt.Sprintf("%s/%s", o.Namespace, model.Name)
+	in.Description = model.Description
+	in.Tags = model.Tags
+	in.Tags.Set("metadata.namespace", o.Namespace)
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/registered-models/create", in)
+	if err != nil {
+		return err
+	}
+
+	var out struct {
+		RegisteredModel `json:"registered_model"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	out.DeepCopyInto(model)
+	trimRegisteredModelNamespacePrefix(model)
+	return nil
+}
+
+// GetRegisteredModel fetches the registered model with the given name.
+// If the registered model is not found, then an error is returned.
+func (c *client) GetRegisteredModel(ctx context.Context, model *RegisteredModel, opts ...GetOption) error {
+	if model.Name == "" {
+		return errors.Errorf("Name must be set")
+	}
+
+	o := &GetOptions{}
+	for _, f := range opts {
+		f.ApplyToGet(o)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/2.0/mlflow/registered-models/get", nil)
+	if err != nil {
+		return err
+	}
+
+	q := req.URL.Query()
+	q.Set("name", fmt.Sprintf("%s/%s", o.Namespace, model.Name))
+	req.URL.RawQuery = q.Encode()
+
+	var out struct {
+		RegisteredModel `json:"registered_model"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	out.DeepCopyInto(model)
+	trimRegisteredModelNamespacePrefix(model)
+	return nil
+}
+
+// DeleteRegisteredModel deletes the registered model with the given name.
+// If the registered model is not found, then an error is returned.
+func (c *client) DeleteRegisteredModel(ctx context.Context, model *RegisteredModel, opts ...DeleteOption) error {
+	if model.Name == "" {
+		return errors.Errorf("Name must be set")
+	}
+
+	o := &DeleteOptions{}
+	for _, f := range opts {
+		f.ApplyToDelete(o)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	var body struct {
+		Name string `json:"name"`
+	}
+	body.Name = fmt.Sprintf("%s/%s", o.Namespace, model.Name)
+
+	req, err := c.newRequest(withIdempotentRetry(ctx), http.MethodPost, "/api/2.0/mlflow/registered-models/delete", body)
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(req, nil); err != nil {
+		if o.IgnoreMissing && IsNotFound(err) {
+			*model = RegisteredModel{}
+			return nil
+		}
+		return err
+	}
+
+	*model = RegisteredModel{}
+	return nil
+}
+
+// searchRegisteredModelsPage fetches a single page of registered models
+// matching o.
+func (c *client) searchRegisteredModelsPage(ctx context.Context, o *ListOptions) (*RegisteredModelList, error) {
+	namespaceFilter := FilterByTag(OpEquals, "metadata.namespace", o.Namespace)
+	filter := Filter(namespaceFilter)
+	if o.Filter != nil {
+		filter = And(namespaceFilter, o.Filter)
+	}
+
+	var in struct {
+		Filter     string   `json:"filter,omitempty"`
+		MaxResults int64    `json:"max_results,omitempty"`
+		PageToken  string   `json:"page_token,omitempty"`
+		OrderBy    []string `json:"order_by,omitempty"`
+	}
+	in.Filter = filter.String()
+	in.MaxResults = o.MaxResults
+	in.PageToken = o.PageToken
+	in.OrderBy = o.OrderBy
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/registered-models/search", in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out RegisteredModelList
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+
+	for i := range out.RegisteredModels {
+		trimRegisteredModelNamespacePrefix(&out.RegisteredModels[i])
+	}
+	return &out, nil
+}
+
+// SearchRegisteredModels returns an iterator that transparently pages
+// through every registered model matching opts.
+func (c *client) SearchRegisteredModels(ctx context.Context, opts ...ListOption) *RegisteredModelIterator {
+	o := &ListOptions{}
+	for _, f := range opts {
+		f.ApplyToList(o)
+	}
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+	return &RegisteredModelIterator{ctx: ctx, client: c, opts: o}
+}
+
+// RegisteredModelIterator pages through the results of
+// SearchRegisteredModels. Callers should loop on Next, read Item within
+// the loop, and check Err once the loop ends.
+type RegisteredModelIterator struct {
+	ctx    context.Context
+	client *client
+	opts   *ListOptions
+
+	page      RegisteredModelList
+	index     int
+	pageToken string
+	done      bool
+	started   bool
+	err       error
+}
+
+// Next advances the iterator, fetching the next page from the server as
+// needed.
+func (it *RegisteredModelIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.page.RegisteredModels) {
+		if it.started && it.done {
+			return false
+		}
+
+		o := *it.opts
+		o.PageToken = it.pageToken
+
+		page, err := it.client.searchRegisteredModelsPage(it.ctx, &o)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.page = *page
+		it.index = 0
+		it.pageToken = page.NextPageToken
+		it.done = page.NextPageToken == ""
+	}
+
+	it.index++
+	return true
+}
+
+// Item returns the registered model at the iterator's current position.
+// It must only be called after a call to Next that returned true.
+func (it *RegisteredModelIterator) Item() *RegisteredModel {
+	return &it.page.RegisteredModels[it.index-1]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *RegisteredModelIterator) Err() error {
+	return it.err
+}
+
+// CreateModelVersion registers a new ModelVersion under the registered
+// model named version.Name.
+func (c *client) CreateModelVersion(ctx context.Context, version *ModelVersion, opts ...CreateOption) error {
+	if version.Name == "" {
+		return errors.Errorf("missing required attribute %q on model version", "Name")
+	}
+
+	o := &CreateOptions{}
+	for _, f := range opts {
+		f.ApplyToCreate(o)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	var in struct {
+		Name        string `json:"name"`
+		Source      string `json:"source"`
+		RunID       string `json:"run_id,omitempty"`
+		Description string `json:"description,omitempty"`
+		Tags        Tags   `json:"tags,omitempty"`
+	}
+	in.Name = fmt.Sprintf("%s/%s", o.Namespace, version.Name)
+	in.Source = version.Source
+	in.RunID = version.RunID
+	in.Description = version.Description
+	in.Tags = version.Tags
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/model-versions/create", in)
+	if err != nil {
+		return err
+	}
+
+	var out struct {
+		ModelVersion `json:"model_version"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	out.DeepCopyInto(version)
+	version.Name = strings.TrimPrefix(version.Name, o.Namespace+"/")
+	return nil
+}
+
+// TransitionModelVersionStage moves a model version to stage, optionally
+// archiving any existing versions already in that stage.
+func (c *client) TransitionModelVersionStage(ctx context.Context, version *ModelVersion, stage ModelVersionStage, archiveExisting bool, opts ...GetOption) error {
+	if version.Name == "" || version.Version == "" {
+		return errors.Errorf("Name and Version must be set")
+	}
+
+	o := &GetOptions{}
+	for _, f := range opts {
+		f.ApplyToGet(o)
+	}
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	var in struct {
+		Name                    string `json:"name"`
+		Version                 string `json:"version"`
+		Stage                   string `json:"stage"`
+		ArchiveExistingVersions bool   `json:"archive_existing_versions"`
+	}
+	in.Name = fmt.Sprintf("%s/%s", o.Namespace, version.Name)
+	in.Version = version.Version
+	in.Stage = string(stage)
+	in.ArchiveExistingVersions = archiveExisting
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/model-versions/transition-stage", in)
+	if err != nil {
+		return err
+	}
+
+	var out struct {
+		ModelVersion `json:"model_version"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	out.DeepCopyInto(version)
+	version.Name = strings.TrimPrefix(version.Name, o.Namespace+"/")
+	return nil
+}
+
+// SetRegisteredModelAlias points alias at version on the named registered
+// model, creating or overwriting it.
+func (c *client) SetRegisteredModelAlias(ctx context.Context, model *RegisteredModel, alias, version string, opts ...GetOption) error {
+	if model.Name == "" {
+		return errors.Errorf("Name must be set")
+	}
+
+	o := &GetOptions{}
+	for _, f := range opts {
+		f.ApplyToGet(o)
+	}
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	var in struct {
+		Name    string `json:"name"`
+		Alias   string `json:"alias"`
+		Version string `json:"version"`
+	}
+	in.Name = fmt.Sprintf("%s/%s", o.Namespace, model.Name)
+	in.Alias = alias
+	in.Version = version
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/registered-models/alias", in)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// DeleteRegisteredModelAlias removes alias from the named registered
+// model.
+func (c *client) DeleteRegisteredModelAlias(ctx context.Context, model *RegisteredModel, alias string, opts ...GetOption) error {
+	if model.Name == "" {
+		return errors.Errorf("Name must be set")
+	}
+
+	o := &GetOptions{}
+	for _, f := range opts {
+		f.ApplyToGet(o)
+	}
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	req, err := c.newRequest(ctx, http.MethodDelete, "/api/2.0/mlflow/registered-models/alias", nil)
+	if err != nil {
+		return err
+	}
+
+	q := req.URL.Query()
+	q.Set("name", fmt.Sprintf("%s/%s", o.Namespace, model.Name))
+	q.Set("alias", alias)
+	req.URL.RawQuery = q.Encode()
+
+	return c.do(req, nil)
+}
+
+// GetModelVersionByAlias resolves alias on the named registered model to
+// its current ModelVersion.
+func (c *client) GetModelVersionByAlias(ctx context.Context, model *RegisteredModel, alias string, opts ...GetOption) (*ModelVersion, error) {
+	if model.Name == "" {
+		return nil, errors.Errorf("Name must be set")
+	}
+
+	o := &GetOptions{}
+	for _, f := range opts {
+		f.ApplyToGet(o)
+	}
+	if o.Namespace == "" {
+		o.Namespace = "default"
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/2.0/mlflow/registered-models/alias", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("name", fmt.Sprintf("%s/%s", o.Namespace, model.Name))
+	q.Set("alias", alias)
+	req.URL.RawQuery = q.Encode()
+
+	var out struct {
+		ModelVersion `json:"model_version"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+
+	version := out.ModelVersion
+	version.Name = strings.TrimPrefix(version.Name, o.Namespace+"/")
+	return &version, nil
+}
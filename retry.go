@@ -0,0 +1,219 @@
+package mlflow
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// APIError is a structured representation of the JSON error envelope
+// MLflow returns on non-200 responses, e.g.
+// {"error_code":"RESOURCE_ALREADY_EXISTS","message":"..."}. Use the
+// IsNotFound, IsAlreadyExists, and IsInvalidParameterValue helpers rather
+// than string-matching Error().
+type APIError struct {
+	// Code is MLflow's error_code, e.g. "RESOURCE_ALREADY_EXISTS". It is
+	// empty if the response body wasn't a recognizable MLflow error
+	// envelope.
+	Code string
+	// HTTPStatus is the response's HTTP status code.
+	HTTPStatus int
+	// Message is MLflow's human-readable error message, or the raw
+	// response body if it couldn't be parsed as an error envelope.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("unexpected status code %d: %s", e.HTTPStatus, e.Message)
+	}
+	return fmt.Sprintf("mlflow: %s: %s (status %d)", e.Code, e.Message, e.HTTPStatus)
+}
+
+// parseAPIError decodes an MLflow error envelope from a non-200 response
+// body, falling back to the raw body as the message if it isn't one.
+func parseAPIError(status int, body []byte) *APIError {
+	var envelope struct {
+		ErrorCode string `json:"error_code"`
+		Message   string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &envelope)
+
+	message := envelope.Message
+	if message == "" {
+		message = string(body)
+	}
+	return &APIError{Code: envelope.ErrorCode, HTTPStatus: status, Message: message}
+}
+
+func apiErrorCodeIs(err error, code string) bool {
+	var apiErr *APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr.Code == code
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an APIError for a missing resource.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr.Code == "RESOURCE_DOES_NOT_EXIST" || apiErr.HTTPStatus == http.StatusNotFound
+	}
+	return false
+}
+
+// IsAlreadyExists reports whether err is an APIError for a resource that
+// already exists.
+func IsAlreadyExists(err error) bool {
+	return apiErrorCodeIs(err, "RESOURCE_ALREADY_EXISTS")
+}
+
+// IsInvalidParameterValue reports whether err is an APIError caused by an
+// invalid request parameter.
+func IsInvalidParameterValue(err error) bool {
+	return apiErrorCodeIs(err, "INVALID_PARAMETER_VALUE")
+}
+
+// RetryPolicy configures how the client retries failed requests.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// A value <= 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the second attempt. Later attempts
+	// double this delay, up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay, before jitter.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of the computed delay to randomize by,
+	// to avoid many clients retrying in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is used by a client created without WithRetryPolicy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.1,
+	}
+}
+
+// idempotentRetryKey marks a request as safe to retry even though its
+// HTTP method isn't inherently idempotent, for MLflow endpoints that use
+// POST for what is semantically an idempotent operation (e.g. deletes
+// keyed by ID).
+type idempotentRetryKey struct{}
+
+// withIdempotentRetry marks requests built from ctx as safe to retry on
+// 429/5xx regardless of HTTP method.
+func withIdempotentRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentRetryKey{}, true)
+}
+
+func isIdempotentRequest(req *http.Request) bool {
+	if isIdempotentMethod(req.Method) {
+		return true
+	}
+	marked, _ := req.Context().Value(idempotentRetryKey{}).(bool)
+	return marked
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || (status >= 500 && status < 600)
+}
+
+func backoffDelay(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay * time.Duration(uint64(1)<<uint(attempt-1))
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if policy.Jitter > 0 {
+		delay += time.Duration(float64(delay) * policy.Jitter * (2*rand.Float64() - 1))
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	return delay
+}
+
+// parseRetryAfter parses an HTTP Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// ClientOpt configures a Client constructed with New.
+type ClientOpt func(*client) error
+
+// WithHTTPClient overrides the *http.Client used to make requests. Useful
+// for tests and for consumers that need a custom transport, e.g. for
+// mTLS or metrics instrumentation.
+func WithHTTPClient(httpClient *http.Client) ClientOpt {
+	return func(c *client) error {
+		c.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithRetryPolicy overrides the client's RetryPolicy. The zero value of
+// RetryPolicy disables retries entirely.
+func WithRetryPolicy(policy RetryPolicy) ClientOpt {
+	return func(c *client) error {
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithAuthenticator sets a function that's called on every outgoing
+// request, e.g. to attach an Authorization header.
+func WithAuthenticator(fn func(*http.Request)) ClientOpt {
+	return func(c *client) error {
+		c.authenticator = fn
+		return nil
+	}
+}
+
+// New constructs a Client for the MLflow tracking server at address.
+func New(address string, opts ...ClientOpt) (Client, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &client{address: u, retryPolicy: DefaultRetryPolicy()}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
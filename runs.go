@@ -0,0 +1,543 @@
+package mlflow
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RunStatus is the lifecycle status of a Run.
+type RunStatus string
+
+const (
+	RunStatusRunning   RunStatus = "RUNNING"
+	RunStatusScheduled RunStatus = "SCHEDULED"
+	RunStatusFinished  RunStatus = "FINISHED"
+	RunStatusFailed    RunStatus = "FAILED"
+	RunStatusKilled    RunStatus = "KILLED"
+)
+
+// Terminal reports whether a run in this status will never transition to
+// another status.
+func (s RunStatus) Terminal() bool {
+	switch s {
+	case RunStatusFinished, RunStatusFailed, RunStatusKilled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Metric is a single logged data point for a metric key on a Run.
+type Metric struct {
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp"`
+	Step      int64   `json:"step,omitempty"`
+}
+
+// Param is an immutable key-value pair logged against a Run, such as a
+// hyperparameter.
+type Param struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RunTag is a key-value pair associated with a Run.
+type RunTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RunInfo holds the metadata MLflow tracks about a Run.
+type RunInfo struct {
+	// RunID is the unique ID for the run. Computed by the server.
+	RunID string `json:"run_id,omitempty"`
+	// ExperimentID is the ID of the experiment this run belongs to. Must
+	// be set when creating a new run.
+	ExperimentID string `json:"experiment_id,omitempty"`
+	// Status is the current lifecycle status of the run.
+	Status RunStatus `json:"status,omitempty"`
+	// StartTime is the unix timestamp (in milliseconds) of when the run
+	// started.
+	StartTime int64 `json:"start_time,omitempty"`
+	// EndTime is the unix timestamp (in milliseconds) of when the run
+	// ended, if it has.
+	EndTime int64 `json:"end_time,omitempty"`
+	// ArtifactURI is the root location where this run's artifacts are
+	// stored. Computed by the server.
+	ArtifactURI string `json:"artifact_uri,omitempty"`
+	// LifecycleStage is the current stage of the run.
+	LifecycleStage LifecycleStage `json:"lifecycle_stage,omitempty"`
+}
+
+// RunData holds the metrics, params, and tags logged against a Run.
+type RunData struct {
+	Metrics []Metric `json:"metrics,omitempty"`
+	Params  []Param  `json:"params,omitempty"`
+	Tags    []RunTag `json:"tags,omitempty"`
+}
+
+// Run is a single execution of user code tracked by MLflow, belonging to
+// an Experiment.
+type Run struct {
+	Info RunInfo `json:"info"`
+	Data RunData `json:"data"`
+}
+
+// DeepCopy returns a deep copy of the Run.
+func (r *Run) DeepCopy() *Run {
+	out := &Run{}
+	r.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies the attributes of the Run into the provided Run.
+func (r *Run) DeepCopyInto(out *Run) {
+	*out = *r
+	out.Data.Metrics = make([]Metric, len(r.Data.Metrics))
+	copy(out.Data.Metrics, r.Data.Metrics)
+	out.Data.Params = make([]Param, len(r.Data.Params))
+	copy(out.Data.Params, r.Data.Params)
+	out.Data.Tags = make([]RunTag, len(r.Data.Tags))
+	copy(out.Data.Tags, r.Data.Tags)
+}
+
+// RunList is a single page of results from SearchRuns.
+type RunList struct {
+	Runs []Run `json:"runs"`
+	// NextPageToken, when non-empty, can be used to retrieve the next
+	// page of results.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// CreateRun starts a new run under run.Info.ExperimentID.
+func (c *client) CreateRun(ctx context.Context, run *Run, opts ...CreateOption) error {
+	if run.Info.ExperimentID == "" {
+		return errors.Errorf("missing required attribute %q on run", "Info.ExperimentID")
+	}
+
+	o := &CreateOptions{}
+	for _, f := range opts {
+		f.ApplyToCreate(o)
+	}
+
+	var in struct {
+		ExperimentID string   `json:"experiment_id"`
+		StartTime    int64    `json:"start_time,omitempty"`
+		Tags         []RunTag `json:"tags,omitempty"`
+	}
+	in.ExperimentID = run.Info.ExperimentID
+	in.StartTime = run.Info.StartTime
+	in.Tags = run.Data.Tags
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/runs/create", in)
+	if err != nil {
+		return err
+	}
+
+	var out struct {
+		Run `json:"run"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	out.DeepCopyInto(run)
+	return nil
+}
+
+// GetRun fetches the run with the given run.Info.RunID.
+func (c *client) GetRun(ctx context.Context, run *Run) error {
+	if run.Info.RunID == "" {
+		return errors.Errorf("RunID must be set")
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/2.0/mlflow/runs/get", nil)
+	if err != nil {
+		return err
+	}
+
+	q := req.URL.Query()
+	q.Set("run_id", run.Info.RunID)
+	req.URL.RawQuery = q.Encode()
+
+	var out struct {
+		Run `json:"run"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	out.DeepCopyInto(run)
+	return nil
+}
+
+// UpdateRun updates the status and end time of run.Info.RunID.
+func (c *client) UpdateRun(ctx context.Context, run *Run) error {
+	if run.Info.RunID == "" {
+		return errors.Errorf("RunID must be set")
+	}
+
+	var in struct {
+		RunID   string `json:"run_id"`
+		Status  string `json:"status,omitempty"`
+		EndTime int64  `json:"end_time,omitempty"`
+	}
+	in.RunID = run.Info.RunID
+	in.Status = string(run.Info.Status)
+	in.EndTime = run.Info.EndTime
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/runs/update", in)
+	if err != nil {
+		return err
+	}
+
+	var out struct {
+		RunInfo `json:"run_info"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return err
+	}
+
+	run.Info = out.RunInfo
+	return nil
+}
+
+// LogParam logs a single param against runID. Params are immutable once
+// set.
+func (c *client) LogParam(ctx context.Context, runID string, param Param) error {
+	var in struct {
+		RunID string `json:"run_id"`
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	in.RunID = runID
+	in.Key = param.Key
+	in.Value = param.Value
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/runs/log-parameter", in)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// LogMetric logs a single metric data point against runID.
+func (c *client) LogMetric(ctx context.Context, runID string, metric Metric) error {
+	var in struct {
+		RunID     string  `json:"run_id"`
+		Key       string  `json:"key"`
+		Value     float64 `json:"value"`
+		Timestamp int64   `json:"timestamp"`
+		Step      int64   `json:"step,omitempty"`
+	}
+	in.RunID = runID
+	in.Key = metric.Key
+	in.Value = metric.Value
+	in.Timestamp = metric.Timestamp
+	in.Step = metric.Step
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/runs/log-metric", in)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// LogBatch logs a batch of metrics, params, and tags against runID in a
+// single call, which is more efficient than logging each individually.
+func (c *client) LogBatch(ctx context.Context, runID string, metrics []Metric, params []Param, tags []RunTag) error {
+	var in struct {
+		RunID   string   `json:"run_id"`
+		Metrics []Metric `json:"metrics,omitempty"`
+		Params  []Param  `json:"params,omitempty"`
+		Tags    []RunTag `json:"tags,omitempty"`
+	}
+	in.RunID = runID
+	in.Metrics = metrics
+	in.Params = params
+	in.Tags = tags
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/runs/log-batch", in)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// searchRunsPage fetches a single page of runs matching o, scoped to
+// experimentIDs.
+func (c *client) searchRunsPage(ctx context.Context, experimentIDs []string, o *ListOptions) (*RunList, error) {
+	var in struct {
+		ExperimentIDs []string `json:"experiment_ids"`
+		Filter        string   `json:"filter,omitempty"`
+		RunViewType   ViewType `json:"run_view_type,omitempty"`
+		MaxResults    int64    `json:"max_results,omitempty"`
+		OrderBy       []string `json:"order_by,omitempty"`
+		PageToken     string   `json:"page_token,omitempty"`
+	}
+	in.ExperimentIDs = experimentIDs
+	if o.Filter != nil {
+		in.Filter = o.Filter.String()
+	}
+	in.RunViewType = o.ViewType
+	in.MaxResults = o.MaxResults
+	in.OrderBy = o.OrderBy
+	in.PageToken = o.PageToken
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/api/2.0/mlflow/runs/search", in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out RunList
+	if err := c.do(req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchRuns returns an iterator that transparently pages through every
+// run matching opts, scoped to experimentIDs.
+func (c *client) SearchRuns(ctx context.Context, experimentIDs []string, opts ...ListOption) *RunIterator {
+	o := &ListOptions{}
+	for _, f := range opts {
+		f.ApplyToList(o)
+	}
+	return &RunIterator{ctx: ctx, client: c, experimentIDs: experimentIDs, opts: o}
+}
+
+// RunIterator pages through the results of SearchRuns. Callers should
+// loop on Next, read Item within the loop, and check Err once the loop
+// ends.
+type RunIterator struct {
+	ctx           context.Context
+	client        *client
+	experimentIDs []string
+	opts          *ListOptions
+
+	page      RunList
+	index     int
+	pageToken string
+	done      bool
+	started   bool
+	err       error
+}
+
+// Next advances the iterator, fetching the next page from the server as
+// needed.
+func (it *RunIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.page.Runs) {
+		if it.started && it.done {
+			return false
+		}
+
+		o := *it.opts
+		o.PageToken = it.pageToken
+
+		page, err := it.client.searchRunsPage(it.ctx, it.experimentIDs, &o)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.started = true
+		it.page = *page
+		it.index = 0
+		it.pageToken = page.NextPageToken
+		it.done = page.NextPageToken == ""
+	}
+
+	it.index++
+	return true
+}
+
+// Item returns the run at the iterator's current position. It must only
+// be called after a call to Next that returned true.
+func (it *RunIterator) Item() *Run {
+	return &it.page.Runs[it.index-1]
+}
+
+// Err returns the first error encountered while paging, if any.
+func (it *RunIterator) Err() error {
+	return it.err
+}
+
+// StreamOptions configure StreamMetricHistory.
+type StreamOptions struct {
+	// Follow keeps the stream open after the full metric history has
+	// been delivered, polling for new points until the run reaches a
+	// terminal status.
+	Follow bool
+
+	// PollInterval is the initial delay between polls while following.
+	// Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// MaxBackoff caps the exponential backoff applied to PollInterval
+	// between polls. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+
+	// IsTerminal overrides how a run's status is classified as
+	// terminal. Defaults to RunStatus.Terminal.
+	IsTerminal func(RunStatus) bool
+}
+
+// StreamOption is a functional option for StreamMetricHistory.
+type StreamOption interface {
+	ApplyToStream(*StreamOptions)
+}
+
+// Follow is a StreamOption that keeps StreamMetricHistory polling for new
+// metric points until the run reaches a terminal status.
+type Follow bool
+
+func (f Follow) ApplyToStream(o *StreamOptions) {
+	o.Follow = bool(f)
+}
+
+// PollInterval is a StreamOption that sets the initial delay between
+// polls while following.
+type PollInterval time.Duration
+
+func (p PollInterval) ApplyToStream(o *StreamOptions) {
+	o.PollInterval = time.Duration(p)
+}
+
+// MaxBackoff is a StreamOption that caps the exponential backoff applied
+// to the poll interval while following.
+type MaxBackoff time.Duration
+
+func (m MaxBackoff) ApplyToStream(o *StreamOptions) {
+	o.MaxBackoff = time.Duration(m)
+}
+
+// TerminalFunc is a StreamOption that overrides how a run's status is
+// classified as terminal while following. Defaults to RunStatus.Terminal.
+type TerminalFunc func(RunStatus) bool
+
+func (t TerminalFunc) ApplyToStream(o *StreamOptions) {
+	o.IsTerminal = t
+}
+
+// StreamMetricHistory returns the full history of metricKey on runID,
+// delivered on the returned channel in order. If StreamOptions.Follow is
+// set, the channel stays open after the existing history is delivered,
+// polling with exponential backoff for new points until the run enters a
+// terminal status. Both channels are closed when streaming ends; any
+// error encountered is sent on the error channel before it closes.
+func (c *client) StreamMetricHistory(ctx context.Context, runID, metricKey string, opts ...StreamOption) (<-chan Metric, <-chan error) {
+	o := &StreamOptions{
+		PollInterval: 2 * time.Second,
+		MaxBackoff:   30 * time.Second,
+		IsTerminal:   RunStatus.Terminal,
+	}
+	for _, f := range opts {
+		f.ApplyToStream(o)
+	}
+
+	metrics := make(chan Metric)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(metrics)
+		defer close(errs)
+
+		emitted := 0
+		backoff := o.PollInterval
+		terminal := false
+		for {
+			// Re-fetches the full history every poll rather than resuming
+			// from the last point; acceptable for a first cut given
+			// MLflow's history endpoint has no "since" cursor.
+			history, err := c.getMetricHistory(ctx, runID, metricKey)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, m := range history[emitted:] {
+				select {
+				case metrics <- m:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+			emitted = len(history)
+
+			if !o.Follow || terminal {
+				return
+			}
+
+			run := &Run{Info: RunInfo{RunID: runID}}
+			if err := c.GetRun(ctx, run); err != nil {
+				errs <- err
+				return
+			}
+			if o.IsTerminal(run.Info.Status) {
+				// Loop once more to pick up any points logged between
+				// the last getMetricHistory call and the run reaching a
+				// terminal status, then exit on the next pass.
+				terminal = true
+				continue
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+
+			backoff *= 2
+			if backoff > o.MaxBackoff {
+				backoff = o.MaxBackoff
+			}
+		}
+	}()
+
+	return metrics, errs
+}
+
+// getMetricHistory fetches the full, ordered history of metricKey on
+// runID, paging through /api/2.0/mlflow/metrics/get-history as needed.
+func (c *client) getMetricHistory(ctx context.Context, runID, metricKey string) ([]Metric, error) {
+	var all []Metric
+	pageToken := ""
+	for {
+		req, err := c.newRequest(ctx, http.MethodGet, "/api/2.0/mlflow/metrics/get-history", nil)
+		if err != nil {
+			return nil, err
+		}
+
+		q := req.URL.Query()
+		q.Set("run_id", runID)
+		q.Set("metric_key", metricKey)
+		if pageToken != "" {
+			q.Set("page_token", pageToken)
+		}
+		req.URL.RawQuery = q.Encode()
+
+		var out struct {
+			Metrics       []Metric `json:"metrics"`
+			NextPageToken string   `json:"next_page_token,omitempty"`
+		}
+		if err := c.do(req, &out); err != nil {
+			return nil, err
+		}
+
+		all = append(all, out.Metrics...)
+		if out.NextPageToken == "" {
+			return all, nil
+		}
+		pageToken = out.NextPageToken
+	}
+}